@@ -190,6 +190,20 @@ func (bb *Buffer) ReadFrom(r io.Reader) (int64, error) {
 	}
 }
 
+// CopyFrom reads exactly n bytes from r, appending them to the buffer.
+// Unlike ReadFrom, which grows geometrically as it reads an unknown amount,
+// CopyFrom knows the size up front and reuses Buffer.B's existing capacity as
+// the staging area, calling Guarantee(n) only once.
+// It follows io.ReadFull's error semantics: err is nil only if exactly n
+// bytes were read.
+func (bb *Buffer) CopyFrom(r io.Reader, n int64) (int64, error) {
+	bb.Guarantee(int(n))
+	bLen := bb.Len()
+	nn, err := io.ReadFull(r, bb.B[bLen:bLen+int(n)])
+	bb.B = bb.B[:bLen+nn]
+	return int64(nn), err
+}
+
 // WriteTo implements io.WriterTo.
 func (bb *Buffer) WriteTo(w io.Writer) (int64, error) {
 	n, err := w.Write(bb.B)
@@ -0,0 +1,179 @@
+package buffer
+
+import "io"
+
+const (
+	// chainMinChunkSize is the capacity of the first chunk allocated by a ChainBuffer.
+	chainMinChunkSize = 128
+	// chainMaxChunkSize caps the growth schedule so a single chunk never exceeds the
+	// default byte pool's largest bucket.
+	chainMaxChunkSize = 8192
+)
+
+// chainChunk is a single pooled byte slice in a ChainBuffer's chunk list.
+type chainChunk struct {
+	b    []byte
+	next *chainChunk
+}
+
+// ChainBuffer stores written data as a linked sequence of pooled []byte chunks
+// instead of a single growing backing array. Unlike Buffer, appending past the
+// current chunk's capacity never copies existing data — it just links in a new
+// chunk — which makes ChainBuffer a better fit for producing multi-MB payloads
+// (e.g. JSON encoders) where Buffer.Guarantee's repeated-copy growth would dominate.
+// The zero value for ChainBuffer is an empty buffer ready to use.
+type ChainBuffer struct {
+	head   *chainChunk
+	tail   *chainChunk
+	length int
+
+	merged []byte
+}
+
+// NewChainBuffer returns an empty, ready-to-use ChainBuffer.
+func NewChainBuffer() *ChainBuffer {
+	return &ChainBuffer{}
+}
+
+// Len returns the number of bytes currently written to the buffer.
+func (cb *ChainBuffer) Len() int {
+	return cb.length
+}
+
+// Write implements io.Writer.
+//
+// The function appends all the data in p to the buffer, allocating new pooled
+// chunks as needed. The returned error is always nil.
+func (cb *ChainBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		cb.ensureTail()
+		free := cb.tail.b[len(cb.tail.b):cap(cb.tail.b)]
+		nn := copy(free, p)
+		cb.tail.b = cb.tail.b[:len(cb.tail.b)+nn]
+		p = p[nn:]
+	}
+	cb.length += n
+	cb.releaseMerged()
+	return n, nil
+}
+
+// releaseMerged returns the cached Bytes() buffer to the pool, if any, and
+// clears the cache.
+func (cb *ChainBuffer) releaseMerged() {
+	if cb.merged == nil {
+		return
+	}
+	defaultPools.bs.Release(cb.merged)
+	cb.merged = nil
+}
+
+// WriteString implements io.StringWriter.
+//
+// The function appends s to the buffer. The returned error is always nil.
+func (cb *ChainBuffer) WriteString(s string) (int, error) {
+	return cb.Write([]byte(s))
+}
+
+// ensureTail makes sure the tail chunk has room for at least one more byte,
+// allocating a new chunk from defaultPools.bs using a growth schedule (start
+// small, then power-of-two up to chainMaxChunkSize) when the current tail is full.
+func (cb *ChainBuffer) ensureTail() {
+	if cb.tail != nil && len(cb.tail.b) < cap(cb.tail.b) {
+		return
+	}
+
+	size := chainMinChunkSize
+	if cb.tail != nil {
+		size = cap(cb.tail.b) * 2
+		if size > chainMaxChunkSize {
+			size = chainMaxChunkSize
+		}
+	}
+
+	c := &chainChunk{b: defaultPools.bs.Make(size)}
+	if cb.tail == nil {
+		cb.head = c
+	} else {
+		cb.tail.next = c
+	}
+	cb.tail = c
+}
+
+// Bytes returns the buffer's contents as a single contiguous slice, lazily
+// concatenating chunks into a final pooled buffer on first call after a write.
+// The returned slice is only valid until the next Write, Reset, or Release.
+func (cb *ChainBuffer) Bytes() []byte {
+	if cb.merged != nil {
+		return cb.merged
+	}
+	buf := defaultPools.bs.Make(cb.length)
+	for c := cb.head; c != nil; c = c.next {
+		buf = append(buf, c.b...)
+	}
+	cb.merged = buf
+	return buf
+}
+
+// WriteTo implements io.WriterTo.
+//
+// Unlike Bytes, WriteTo writes chunk-by-chunk without concatenating, which is
+// a big win for large payloads.
+func (cb *ChainBuffer) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for c := cb.head; c != nil; c = c.next {
+		n, err := w.Write(c.b)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// Reset truncates the buffer to empty, releasing every chunk but the first
+// back to the pool so the buffer can be reused without reallocating.
+func (cb *ChainBuffer) Reset() {
+	if cb.head != nil {
+		for c := cb.head.next; c != nil; {
+			next := c.next
+			defaultPools.bs.Release(c.b)
+			c = next
+		}
+		cb.head.b = cb.head.b[:0]
+		cb.head.next = nil
+		cb.tail = cb.head
+	}
+	cb.length = 0
+	cb.releaseMerged()
+}
+
+// Close implements io.Closer.
+func (cb *ChainBuffer) Close() error {
+	if cb.Release() {
+		return nil
+	}
+	return ErrClose
+}
+
+// Release returns every chunk to defaultPools.bs and resets the buffer to empty.
+func (cb *ChainBuffer) Release() bool {
+	ok := true
+	for c := cb.head; c != nil; {
+		next := c.next
+		if !defaultPools.bs.Release(c.b) {
+			ok = false
+		}
+		c = next
+	}
+	if cb.merged != nil {
+		if !defaultPools.bs.Release(cb.merged) {
+			ok = false
+		}
+	}
+	cb.head = nil
+	cb.tail = nil
+	cb.length = 0
+	cb.merged = nil
+	return ok
+}
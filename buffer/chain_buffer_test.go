@@ -0,0 +1,75 @@
+package buffer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestChainBufferWriteBytesWriteTo(t *testing.T) {
+	cb := NewChainBuffer()
+	want := strings.Repeat("ab", 1000) // forces multiple chunks past chainMinChunkSize
+
+	if _, err := cb.WriteString(want); err != nil {
+		t.Fatalf("WriteString error: %v", err)
+	}
+	if cb.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", cb.Len(), len(want))
+	}
+	if got := string(cb.Bytes()); got != want {
+		t.Fatalf("Bytes() = %q, want %q", got, want)
+	}
+
+	var dst bytes.Buffer
+	n, err := cb.WriteTo(&dst)
+	if err != nil {
+		t.Fatalf("WriteTo error: %v", err)
+	}
+	if n != int64(len(want)) || dst.String() != want {
+		t.Fatalf("WriteTo produced %d bytes %q, want %d bytes matching input", n, dst.String(), len(want))
+	}
+}
+
+func TestChainBufferWriteAfterBytesReleasesMergedBuffer(t *testing.T) {
+	cb := NewChainBuffer()
+	cb.WriteString("first")
+	_ = cb.Bytes() // populates the cached merged buffer
+
+	before := sumBsStats()
+	cb.WriteString("second")
+	after := sumBsStats()
+
+	if after.puts <= before.puts {
+		t.Fatalf("Write after Bytes() didn't release the cached buffer back to the pool: puts before=%d after=%d", before.puts, after.puts)
+	}
+}
+
+func TestChainBufferResetReleasesMergedBuffer(t *testing.T) {
+	cb := NewChainBuffer()
+	cb.WriteString("first")
+	_ = cb.Bytes() // populates the cached merged buffer
+
+	before := sumBsStats()
+	cb.Reset()
+	after := sumBsStats()
+
+	if after.puts <= before.puts {
+		t.Fatalf("Reset didn't release the cached buffer back to the pool: puts before=%d after=%d", before.puts, after.puts)
+	}
+	if cb.Len() != 0 {
+		t.Fatalf("Len() after Reset = %d, want 0", cb.Len())
+	}
+}
+
+type bsStatTotals struct {
+	puts uint64
+}
+
+// sumBsStats totals defaultPools.bs's per-bucket Puts counter.
+func sumBsStats() bsStatTotals {
+	var total bsStatTotals
+	for _, s := range defaultPools.bs.Stats() {
+		total.puts += s.Puts
+	}
+	return total
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -12,6 +13,10 @@ const (
 	minCapacity    = 2
 	defaultMinSize = 2
 	defaultMaxSize = 8192
+
+	// defaultCalibrationThreshold is the number of Get/Release operations between
+	// automatic recalibrations when calibration is enabled via WithCalibration.
+	defaultCalibrationThreshold = 42000
 )
 
 var defaultCapacityPools = NewCapacityPools(defaultMinSize, defaultMaxSize)
@@ -21,11 +26,68 @@ type CapacityPools struct {
 	maxSize  int
 	maxIndex int
 	pools    []*bytesPool
+
+	// calibration state, all zero values when WithCalibration is not used.
+	calibThreshold int64
+	calibOps       int64
+	calibMu        sync.Mutex
+	calibCounters  []uint64
+	active         []int32
+	defaultIdx     int32
+}
+
+// Option configures a CapacityPools at construction time.
+type Option func(*CapacityPools)
+
+// WithCalibration opts a CapacityPools into adaptive self-tuning: every
+// threshold Get/Release operations it recomputes, from the observed size
+// histogram, which bucket MakeMin/NewMin should treat as the default and
+// prunes buckets sitting outside the observed low/high percentile range.
+// A threshold <= 0 falls back to defaultCalibrationThreshold. The fixed
+// power-of-two layout remains the default when this option is not used.
+func WithCalibration(threshold int) Option {
+	if threshold <= 0 {
+		threshold = defaultCalibrationThreshold
+	}
+	return func(p *CapacityPools) {
+		p.calibThreshold = int64(threshold)
+		p.calibCounters = make([]uint64, len(p.pools))
+		p.active = make([]int32, len(p.pools))
+		for i := range p.active {
+			p.active[i] = 1
+		}
+	}
 }
 
 type bytesPool struct {
 	capacity int
 	pool     sync.Pool
+
+	// telemetry, all read/written atomically.
+	gets              uint64
+	misses            uint64
+	puts              uint64
+	oversizeDiscards  uint64
+	undersizeDiscards uint64
+}
+
+// PoolStat is a snapshot of one size class's telemetry, as returned by
+// (*CapacityPools).Stats.
+type PoolStat struct {
+	// Capacity is the bucket's fixed buffer capacity.
+	Capacity int
+	// Gets is the number of New/Make calls served from this bucket.
+	Gets uint64
+	// Misses is the subset of Gets that found the pool empty and allocated
+	// through make instead of reusing a buffer.
+	Misses uint64
+	// Puts is the number of Release calls that returned a buffer to this bucket.
+	Puts uint64
+	// Discards is the number of Release calls rejected at this bucket:
+	// undersize buffers (cap short of the bucket's own capacity), buffers for
+	// a bucket that Calibrate has pruned, and, counted on the largest bucket
+	// only, oversize buffers (cap > maxSize).
+	Discards uint64
 }
 
 // InitDefaultPools initialize to the default pool.
@@ -34,7 +96,9 @@ func InitDefaultPools(minSize, maxSize int) {
 }
 
 // NewCapacityPools divide into multiple pools according to the capacity scale.
-func NewCapacityPools(minSize, maxSize int) *CapacityPools {
+// Pass WithCalibration to opt into adaptive self-tuning; by default the set of
+// buckets is fixed for the lifetime of the CapacityPools.
+func NewCapacityPools(minSize, maxSize int, opts ...Option) *CapacityPools {
 	var pools []*bytesPool
 	if minSize < minCapacity {
 		minSize = minCapacity
@@ -48,12 +112,16 @@ func NewCapacityPools(minSize, maxSize int) *CapacityPools {
 	}
 	pools = append(pools, newBytesPool(maxSize))
 
-	return &CapacityPools{
+	p := &CapacityPools{
 		minSize:  minSize,
 		maxSize:  maxSize,
 		maxIndex: len(pools) - 1,
 		pools:    pools,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 func newBytesPool(size int) *bytesPool {
@@ -83,19 +151,27 @@ func (p *CapacityPools) MakeMax() []byte {
 }
 
 func (p *CapacityPools) MakeMin() []byte {
-	return p.New(p.minSize)[:0]
+	return p.New(p.defaultSize())[:0]
 }
 
 // New return bytes of the specified size.
 // Length is size, may contain old data.
 func (p *CapacityPools) New(size int) (buf []byte) {
-	bp := p.getPool(size)
-	if bp == nil {
+	idx := p.exactPoolIndex(size)
+	if idx < 0 {
+		return make([]byte, size)
+	}
+	idx = p.skipInactive(idx, size)
+	if idx < 0 {
 		return make([]byte, size)
 	}
+	p.recordOp(idx)
+	bp := p.pools[idx]
+	atomic.AddUint64(&bp.gets, 1)
 
 	ptr, _ := bp.pool.Get().(unsafe.Pointer)
 	if ptr == nil {
+		atomic.AddUint64(&bp.misses, 1)
 		return make([]byte, size, bp.capacity)
 	}
 
@@ -116,34 +192,56 @@ func (p *CapacityPools) NewMax() []byte {
 }
 
 func (p *CapacityPools) NewMin() []byte {
-	return p.New(p.minSize)
+	return p.New(p.defaultSize())
 }
 
 // Release put it back into the pool of the corresponding scale.
 // Discard buffer larger than the maximum capacity.
 func (p *CapacityPools) Release(buf []byte) bool {
 	n := cap(buf)
-	if n == 0 || n > p.maxSize {
+	if n == 0 {
+		return false
+	}
+	if n > p.maxSize {
+		atomic.AddUint64(&p.pools[p.maxIndex].oversizeDiscards, 1)
+		return false
+	}
+	idx := p.exactPoolIndex(n)
+	if idx < 0 {
+		atomic.AddUint64(&p.pools[p.maxIndex].oversizeDiscards, 1)
+		return false
+	}
+	bp := p.pools[idx]
+	if n != bp.capacity {
+		atomic.AddUint64(&bp.undersizeDiscards, 1)
 		return false
 	}
-	bp := p.getPool(cap(buf))
-	if bp == nil || n != bp.capacity {
+	if p.calibThreshold != 0 && atomic.LoadInt32(&p.active[idx]) == 0 {
+		// Calibrate pruned this bucket; accepting the Put would let a buffer
+		// sit inert in a sync.Pool that New will never draw from again.
+		atomic.AddUint64(&bp.undersizeDiscards, 1)
 		return false
 	}
+	p.recordOp(idx)
+	atomic.AddUint64(&bp.puts, 1)
 	// array pointer
 	bp.pool.Put(unsafe.Pointer(&buf[:1][0]))
 	return true
 }
 
-func (p *CapacityPools) getPool(size int) *bytesPool {
+// exactPoolIndex returns the index of the smallest bucket whose capacity is
+// >= size, ignoring calibration (used to validate a Release and as the
+// starting point for a Get lookup). Any size <= minSize, negative ones
+// included, maps to 0; it returns -1 if size is larger than maxSize.
+func (p *CapacityPools) exactPoolIndex(size int) int {
 	if size <= p.minSize {
-		return p.pools[0]
+		return 0
 	}
 	if size == p.maxSize {
-		return p.pools[p.maxIndex]
+		return p.maxIndex
 	}
 	if size > p.maxSize {
-		return nil
+		return -1
 	}
 
 	idx := int(math.Ceil(math.Log2(float64(size) / float64(p.minSize))))
@@ -151,10 +249,126 @@ func (p *CapacityPools) getPool(size int) *bytesPool {
 		idx = 0
 	}
 	if idx > p.maxIndex {
-		return nil
+		return -1
 	}
 
-	return p.pools[idx]
+	return idx
+}
+
+// skipInactive advances idx past buckets calibration has pruned, since a Get
+// still needs a capacity >= size. The jump is bounded to buckets of capacity
+// <= 2*size: beyond that a pruned run of buckets would otherwise hand back a
+// wildly oversized buffer, so the caller is told to fall back to a bare make
+// instead. It returns -1 if no bucket within that bound is active.
+func (p *CapacityPools) skipInactive(idx, size int) int {
+	if p.calibThreshold == 0 {
+		return idx
+	}
+	for idx <= p.maxIndex && atomic.LoadInt32(&p.active[idx]) == 0 {
+		idx++
+		if idx <= p.maxIndex && p.pools[idx].capacity > 2*size {
+			return -1
+		}
+	}
+	if idx > p.maxIndex {
+		return -1
+	}
+	return idx
+}
+
+// defaultSize returns the bucket capacity MakeMin/NewMin should use: the
+// calibrated default once enough samples have been observed, or minSize
+// otherwise.
+func (p *CapacityPools) defaultSize() int {
+	if p.calibThreshold == 0 {
+		return p.minSize
+	}
+	idx := int(atomic.LoadInt32(&p.defaultIdx))
+	if idx < 0 || idx >= len(p.pools) {
+		return p.minSize
+	}
+	return p.pools[idx].capacity
+}
+
+// recordOp tallies a Get/Release against idx's bucket and triggers a
+// recalibration once calibThreshold operations have been observed.
+func (p *CapacityPools) recordOp(idx int) {
+	if p.calibThreshold == 0 {
+		return
+	}
+	if idx >= 0 && idx < len(p.calibCounters) {
+		atomic.AddUint64(&p.calibCounters[idx], 1)
+	}
+	if atomic.AddInt64(&p.calibOps, 1) >= p.calibThreshold {
+		p.Calibrate()
+	}
+}
+
+// Calibrate recomputes, from the size histogram accumulated since the last
+// call, which bucket is the most requested (used by MakeMin/NewMin going
+// forward) and which buckets sit outside the observed low/high percentile
+// range and should stop accepting releases. It resets the histogram for the
+// next window. Safe to call manually at any time in addition to the automatic
+// trigger driven by WithCalibration.
+func (p *CapacityPools) Calibrate() {
+	p.calibMu.Lock()
+	defer p.calibMu.Unlock()
+
+	counters := make([]uint64, len(p.calibCounters))
+	for i := range counters {
+		counters[i] = atomic.SwapUint64(&p.calibCounters[i], 0)
+	}
+	atomic.StoreInt64(&p.calibOps, 0)
+
+	var total uint64
+	best, bestHits := 0, uint64(0)
+	for i, c := range counters {
+		total += c
+		if c > bestHits {
+			best, bestHits = i, c
+		}
+	}
+	if total == 0 {
+		return
+	}
+	atomic.StoreInt32(&p.defaultIdx, int32(best))
+
+	const lowFloor, highCeiling = 0.01, 0.99
+	var cumulative uint64
+	for i, c := range counters {
+		below := float64(cumulative) / float64(total)
+		cumulative += c
+		frac := float64(cumulative) / float64(total)
+		active := int32(1)
+		if frac < lowFloor || below > highCeiling {
+			active = 0
+		}
+		if i == best || i == 0 || i == p.maxIndex {
+			active = 1 // never prune the calibrated default, the floor, or the ceiling bucket
+		}
+		atomic.StoreInt32(&p.active[i], active)
+	}
+}
+
+// Stats returns a per-bucket telemetry snapshot, ordered from smallest to
+// largest capacity.
+func (p *CapacityPools) Stats() []PoolStat {
+	stats := make([]PoolStat, len(p.pools))
+	for i, bp := range p.pools {
+		stats[i] = PoolStat{
+			Capacity: bp.capacity,
+			Gets:     atomic.LoadUint64(&bp.gets),
+			Misses:   atomic.LoadUint64(&bp.misses),
+			Puts:     atomic.LoadUint64(&bp.puts),
+			Discards: atomic.LoadUint64(&bp.oversizeDiscards) + atomic.LoadUint64(&bp.undersizeDiscards),
+		}
+	}
+	return stats
+}
+
+// Stats returns defaultCapacityPools' per-bucket telemetry snapshot.
+func Stats() []PoolStat {
+	return defaultCapacityPools.Stats()
 }
 
 func Make(size int) []byte {
@@ -0,0 +1,56 @@
+package bytespool
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestCalibrateSetsDefaultToMostRequestedBucket(t *testing.T) {
+	p := NewCapacityPools(defaultMinSize, defaultMaxSize, WithCalibration(1))
+
+	// Hammer the 64 B bucket so it's the clear majority of the histogram,
+	// then let the threshold of 1 trigger a recalibration on every op.
+	for i := 0; i < 10; i++ {
+		p.Release(p.New(64))
+	}
+
+	if got := p.defaultSize(); got != 64 {
+		t.Fatalf("defaultSize() = %d, want 64 (the most-requested bucket)", got)
+	}
+	if got := len(p.MakeMin()); got != 0 {
+		t.Fatalf("len(MakeMin()) = %d, want 0", got)
+	}
+	if got := cap(p.NewMin()); got != 64 {
+		t.Fatalf("cap(NewMin()) = %d, want 64", got)
+	}
+}
+
+func TestCalibratePrunesColdBucketsAndReleaseDiscardsThem(t *testing.T) {
+	p := NewCapacityPools(defaultMinSize, defaultMaxSize, WithCalibration(1))
+
+	// Overwhelmingly favor the largest bucket so everything else falls
+	// below the low floor and gets pruned, except the floor/ceiling/default
+	// buckets Calibrate always keeps active.
+	for i := 0; i < 200; i++ {
+		p.Release(p.New(defaultMaxSize))
+	}
+
+	idx := p.exactPoolIndex(64)
+	if atomic.LoadInt32(&p.active[idx]) != 0 {
+		t.Fatalf("bucket for 64 B wasn't pruned after hammering the max-size bucket")
+	}
+
+	// A buffer matching a pruned bucket's exact capacity must be discarded,
+	// not accepted into a sync.Pool that New can never draw from again.
+	buf := make([]byte, 64, 64)
+	if p.Release(buf) {
+		t.Fatalf("Release(64) on a pruned bucket = true, want false (discard)")
+	}
+
+	// New for a small size whose bucket is pruned must not return something
+	// wildly oversized; skipInactive is bounded to capacity <= 2*size.
+	got := p.New(64)
+	if cap(got) > 2*64 {
+		t.Fatalf("New(64) with its bucket pruned returned cap=%d, want <= %d (bounded fallback)", cap(got), 2*64)
+	}
+}
@@ -0,0 +1,200 @@
+package bytespool
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+const defaultChannelBucketDepth = 16
+
+// channelBucket is one size class of a ChannelPools: a bounded channel of
+// spare buffers plus the baseline capacity misses are allocated with.
+type channelBucket struct {
+	half uint64 // atomic: Get calls that pushed back an oversized head and allocated fresh; kept first for 64-bit alignment on 32-bit platforms
+
+	capacity int
+	baseline int
+	ch       chan []byte
+}
+
+// ChannelPools is an alternative to CapacityPools that retains spare buffers
+// in buffered channels instead of sync.Pool, modeled on goleveldb's buffer
+// pool. Unlike sync.Pool, the GC can't empty it at any time, which gives
+// callers with bounded concurrency a deterministic cap on retained memory
+// (perBucketDepth per size class).
+//
+// It also behaves differently from CapacityPools in two ways:
+//   - a pool miss allocates make([]byte, n, baseline) with baseline one size
+//     class above n, so the buffer is likely to land back in a reusable
+//     bucket on Release instead of being stuck at an awkward capacity;
+//   - Release, given a buffer whose capacity falls between buckets, stores a
+//     freshly allocated buffer in the largest smaller bucket it comfortably
+//     (>= 2x) covers instead of just returning false (the passed buffer
+//     itself is dropped), and Get pushes back a head-of-channel buffer that's
+//     more than 2x the requested size rather than handing out something
+//     wastefully large.
+type ChannelPools struct {
+	minSize  int
+	maxSize  int
+	maxIndex int
+	buckets  []*channelBucket
+}
+
+// ChannelPoolStat is a snapshot of one size class's telemetry, as returned by
+// (*ChannelPools).Stats.
+type ChannelPoolStat struct {
+	Capacity int
+	Baseline int
+	// Half counts Get calls where the head-of-channel buffer was more than 2x
+	// the requested size and got pushed back in favor of a fresh allocation.
+	Half uint64
+}
+
+// NewChannelPools divides [minSize, maxSize] into the same power-of-two size
+// classes as NewCapacityPools, each backed by a channel buffered to
+// perBucketDepth spare buffers. perBucketDepth <= 0 falls back to
+// defaultChannelBucketDepth.
+func NewChannelPools(minSize, maxSize, perBucketDepth int) *ChannelPools {
+	if minSize < minCapacity {
+		minSize = minCapacity
+	}
+	if maxSize < minSize {
+		maxSize = minSize
+	}
+	if perBucketDepth <= 0 {
+		perBucketDepth = defaultChannelBucketDepth
+	}
+
+	var capacities []int
+	for i := minSize; i < maxSize; i *= 2 {
+		capacities = append(capacities, i)
+	}
+	capacities = append(capacities, maxSize)
+
+	buckets := make([]*channelBucket, len(capacities))
+	for i, c := range capacities {
+		baseline := c
+		if i+1 < len(capacities) {
+			baseline = capacities[i+1]
+		}
+		buckets[i] = &channelBucket{
+			capacity: c,
+			baseline: baseline,
+			ch:       make(chan []byte, perBucketDepth),
+		}
+	}
+
+	return &ChannelPools{
+		minSize:  minSize,
+		maxSize:  maxSize,
+		maxIndex: len(buckets) - 1,
+		buckets:  buckets,
+	}
+}
+
+// bucketIndex returns the index of the smallest bucket with capacity >= size,
+// or -1 if size is larger than maxSize.
+func (cp *ChannelPools) bucketIndex(size int) int {
+	if size <= cp.minSize {
+		return 0
+	}
+	if size == cp.maxSize {
+		return cp.maxIndex
+	}
+	if size > cp.maxSize {
+		return -1
+	}
+
+	idx := int(math.Ceil(math.Log2(float64(size) / float64(cp.minSize))))
+	if idx < 0 {
+		idx = 0
+	}
+	if idx > cp.maxIndex {
+		return -1
+	}
+	return idx
+}
+
+// New returns bytes of the specified size, length is size and may contain
+// old data. A pool miss allocates with the bucket's baseline capacity rather
+// than size itself.
+func (cp *ChannelPools) New(size int) []byte {
+	idx := cp.bucketIndex(size)
+	if idx < 0 {
+		return make([]byte, size)
+	}
+	bucket := cp.buckets[idx]
+
+	select {
+	case buf := <-bucket.ch:
+		if cap(buf) > 2*size {
+			select {
+			case bucket.ch <- buf:
+			default:
+			}
+			atomic.AddUint64(&bucket.half, 1)
+			return make([]byte, size, bucket.baseline)
+		}
+		return buf[:size]
+	default:
+		return make([]byte, size, bucket.baseline)
+	}
+}
+
+// Make returns an empty bytes slice; length is 0, capacity matches New(capacity).
+func (cp *ChannelPools) Make(capacity int) []byte {
+	return cp.New(capacity)[:0]
+}
+
+// Release puts buf back into the bucket matching its capacity. A buffer that
+// doesn't land on a bucket exactly is walked down to the largest smaller
+// bucket it's still at least 2x the capacity of, and re-pooled there instead
+// of being discarded.
+func (cp *ChannelPools) Release(buf []byte) bool {
+	n := cap(buf)
+	if n == 0 || n > cp.maxSize {
+		return false
+	}
+	idx := cp.bucketIndex(n)
+	if idx < 0 {
+		return false
+	}
+	bucket := cp.buckets[idx]
+	store := buf
+	if n != bucket.capacity {
+		for idx > 0 && n < 2*cp.buckets[idx-1].capacity {
+			idx--
+		}
+		if idx == 0 {
+			return false
+		}
+		bucket = cp.buckets[idx-1]
+		// Re-slicing buf down to bucket.capacity would still reference buf's
+		// original, larger backing array, pinning all of it in memory for as
+		// long as the channel slot isn't popped. Allocate a fresh,
+		// bucket-sized buffer instead so the oversized array is actually
+		// released to the GC.
+		store = make([]byte, bucket.capacity, bucket.capacity)
+	}
+
+	select {
+	case bucket.ch <- store:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stats returns a per-bucket telemetry snapshot, ordered from smallest to
+// largest capacity.
+func (cp *ChannelPools) Stats() []ChannelPoolStat {
+	stats := make([]ChannelPoolStat, len(cp.buckets))
+	for i, b := range cp.buckets {
+		stats[i] = ChannelPoolStat{
+			Capacity: b.capacity,
+			Baseline: b.baseline,
+			Half:     atomic.LoadUint64(&b.half),
+		}
+	}
+	return stats
+}
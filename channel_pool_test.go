@@ -0,0 +1,65 @@
+package bytespool
+
+import "testing"
+
+func TestChannelPoolsNewMissUsesBaseline(t *testing.T) {
+	cp := NewChannelPools(16, 256, 4)
+
+	buf := cp.New(20)
+	if len(buf) != 20 {
+		t.Fatalf("len(buf) = %d, want 20", len(buf))
+	}
+	if cap(buf) <= 20 {
+		t.Fatalf("cap(buf) = %d, want > 20 (a pool miss should allocate with headroom via baseline)", cap(buf))
+	}
+}
+
+func TestChannelPoolsReleaseTruncatesCapacityToBucket(t *testing.T) {
+	cp := NewChannelPools(16, 256, 4)
+
+	// 70 doesn't land on a bucket boundary exactly, so Release must downsize
+	// it into whichever smaller bucket it's re-pooled into rather than
+	// keeping its original, larger capacity.
+	big := make([]byte, 70, 70)
+	if !cp.Release(big) {
+		t.Fatalf("Release(cap 70) = false, want true")
+	}
+
+	idx := cp.bucketIndex(70)
+	for i := idx; i >= 0; i-- {
+		select {
+		case got := <-cp.buckets[i].ch:
+			if cap(got) != cp.buckets[i].capacity {
+				t.Fatalf("buffer re-pooled into bucket %d (capacity %d) kept cap %d, want cap truncated to the bucket's capacity", i, cp.buckets[i].capacity, cap(got))
+			}
+			return
+		default:
+		}
+	}
+	t.Fatalf("Release(cap 70) didn't land in any bucket at or below index %d", idx)
+}
+
+func TestChannelPoolsGetPushesBackOversizedHead(t *testing.T) {
+	cp := NewChannelPools(16, 256, 4)
+
+	// An exact match for bucket 0 (capacity 16).
+	exact := make([]byte, 16, 16)
+	if !cp.Release(exact) {
+		t.Fatalf("Release(cap 16) = false, want true")
+	}
+
+	// 16 is more than 2x the requested 5, so Get should push it back and
+	// allocate fresh rather than handing out a wastefully large buffer.
+	buf := cp.New(5)
+	if cap(buf) == 16 {
+		t.Fatalf("New(5) handed out the oversized 16-cap buffer instead of allocating fresh")
+	}
+
+	var half uint64
+	for _, s := range cp.Stats() {
+		half += s.Half
+	}
+	if half == 0 {
+		t.Fatalf("Stats() reports Half = 0, want > 0 after an oversized head was pushed back")
+	}
+}
@@ -0,0 +1,61 @@
+package bytespool
+
+import "io"
+
+// defaultCopyBufferSize is roughly 16 pages, a reasonable default staging
+// size for proxying request/response bodies.
+const defaultCopyBufferSize = 16 * 4096
+
+// Copier performs io.Copy-style copies using a staging buffer acquired from a
+// dedicated CapacityPools instead of allocating a fresh make([]byte, 32*1024)
+// per call the way io.Copy does. It uses its own pool, sized to size, rather
+// than defaultCapacityPools, since size is typically far larger than the
+// small buffers defaultCapacityPools is tuned for and would otherwise just be
+// discarded as oversize on every Release.
+type Copier struct {
+	size  int
+	pools *CapacityPools
+}
+
+// NewCopier returns a Copier whose staging buffer is size bytes.
+// size <= 0 falls back to defaultCopyBufferSize.
+func NewCopier(size int) *Copier {
+	if size <= 0 {
+		size = defaultCopyBufferSize
+	}
+	return &Copier{
+		size:  size,
+		pools: NewCapacityPools(defaultMinSize, size),
+	}
+}
+
+var defaultCopier = NewCopier(0)
+
+// Copy is the pool-backed equivalent of io.Copy.
+func (c *Copier) Copy(dst io.Writer, src io.Reader) (int64, error) {
+	return c.CopyBuffer(dst, src, nil)
+}
+
+// CopyBuffer is the pool-backed equivalent of io.CopyBuffer: if buf is empty,
+// a staging buffer is acquired from c's pool and released back to it when the
+// copy is done.
+func (c *Copier) CopyBuffer(dst io.Writer, src io.Reader, buf []byte) (int64, error) {
+	if len(buf) != 0 {
+		return io.CopyBuffer(dst, src, buf)
+	}
+	staging := c.pools.New(c.size)
+	defer c.pools.Release(staging)
+	return io.CopyBuffer(dst, src, staging)
+}
+
+// Copy is the pool-backed equivalent of io.Copy, using a staging buffer sized
+// by defaultCopyBufferSize.
+func Copy(dst io.Writer, src io.Reader) (int64, error) {
+	return defaultCopier.Copy(dst, src)
+}
+
+// CopyBuffer is the pool-backed equivalent of io.CopyBuffer, falling back to a
+// pooled staging buffer sized by defaultCopyBufferSize when buf is empty.
+func CopyBuffer(dst io.Writer, src io.Reader, buf []byte) (int64, error) {
+	return defaultCopier.CopyBuffer(dst, src, buf)
+}
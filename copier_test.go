@@ -0,0 +1,56 @@
+package bytespool
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCopyRoundTrip(t *testing.T) {
+	const want = "hello pooled copy"
+	src := bytes.NewReader([]byte(want))
+	var dst bytes.Buffer
+
+	n, err := Copy(&dst, src)
+	if err != nil {
+		t.Fatalf("Copy error: %v", err)
+	}
+	if n != int64(len(want)) {
+		t.Fatalf("Copy n = %d, want %d", n, len(want))
+	}
+	if got := dst.String(); got != want {
+		t.Fatalf("Copy produced %q, want %q", got, want)
+	}
+}
+
+func TestCopierDefaultStagingSizeFitsItsOwnPool(t *testing.T) {
+	if defaultCopyBufferSize != defaultCopier.size {
+		t.Fatalf("defaultCopier.size = %d, want defaultCopyBufferSize = %d", defaultCopier.size, defaultCopyBufferSize)
+	}
+
+	before := sumPoolStats(defaultCopier.pools.Stats())
+
+	src := bytes.NewReader(make([]byte, 1024))
+	var dst bytes.Buffer
+	if _, err := Copy(&dst, src); err != nil {
+		t.Fatalf("Copy error: %v", err)
+	}
+
+	after := sumPoolStats(defaultCopier.pools.Stats())
+	if after.Puts <= before.Puts {
+		t.Fatalf("Copy() staging buffer wasn't released back to its pool: Puts before=%d after=%d", before.Puts, after.Puts)
+	}
+	if after.Discards > before.Discards {
+		t.Fatalf("Copy() staging buffer was discarded as oversize: Discards before=%d after=%d", before.Discards, after.Discards)
+	}
+}
+
+func sumPoolStats(stats []PoolStat) PoolStat {
+	var total PoolStat
+	for _, s := range stats {
+		total.Gets += s.Gets
+		total.Misses += s.Misses
+		total.Puts += s.Puts
+		total.Discards += s.Discards
+	}
+	return total
+}